@@ -0,0 +1,236 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gosow9/csv2avro/internal/gocheck"
+)
+
+var GOCHECK = `
+
+██████╗  ██████╗        ██████╗██╗  ██╗███████╗ ██████╗██╗  ██╗
+██╔════╝ ██╔═══██╗      ██╔════╝██║  ██║██╔════╝██╔════╝██║ ██╔╝
+██║  ███╗██║   ██║█████╗██║     ███████║█████╗  ██║     █████╔╝
+██║   ██║██║   ██║╚════╝██║     ██╔══██║██╔══╝  ██║     ██╔═██╗
+╚██████╔╝╚██████╔╝      ╚██████╗██║  ██║███████╗╚██████╗██║  ██╗
+ ╚═════╝  ╚═════╝        ╚═════╝╚═╝  ╚═╝╚══════╝ ╚═════╝╚═╝  ╚═╝
+
+  `
+
+func main() {
+	start := time.Now()
+
+	// Define the flag to check for CID
+	checkCID := flag.Bool("cid", true, "Check for client identifying data (email, phone, names)")
+	workers := flag.Int("workers", runtime.NumCPU(), "Number of concurrent scan workers")
+	checkEntropy := flag.Bool("entropy", false, "Flag high-entropy tokens that may be unkeyworded secrets")
+	updateBaseline := flag.Bool("update-baseline", false, "Accept all current findings into .gocheck-baseline.json")
+	format := flag.String("format", "text", "Output format: text, json, or sarif")
+	outPath := flag.String("o", "", "Write the report to this file instead of stdout")
+	flag.Parse()
+
+	// -workers 0 (or negative) must not silently stop the scan while
+	// still exiting 0; clamp to the minimum that keeps it running.
+	if *workers < 1 {
+		*workers = 1
+	}
+
+	// A non-text format is meant to be redirected or piped straight into
+	// a CI tool, so the banner and status line go to stderr and leave
+	// stdout holding nothing but the report.
+	var bannerOut io.Writer = os.Stdout
+	if *format != "text" {
+		bannerOut = os.Stderr
+	}
+	fmt.Fprintln(bannerOut, GOCHECK)
+	fmt.Fprintln(bannerOut, "\033[1;34mGOCHECK: Scanning files for sensitive information...\033[0m")
+
+	cfg, err := gocheck.LoadConfig()
+	if err != nil {
+		fmt.Println("\033[1;31mError loading gocheck config: \033[0m", err)
+		os.Exit(1)
+	}
+	rules, err := cfg.Compile()
+	if err != nil {
+		fmt.Println("\033[1;31mError compiling gocheck rules: \033[0m", err)
+		os.Exit(1)
+	}
+	baseline, err := gocheck.LoadBaseline()
+	if err != nil {
+		fmt.Println("\033[1;31mError loading gocheck baseline: \033[0m", err)
+		os.Exit(1)
+	}
+
+	// Get the added lines for the current commit, grouped by file
+	hunks, err := gocheck.GetAddedHunks()
+	if err != nil {
+		fmt.Println("\033[1;31mError fetching git diff: \033[0m", err)
+		os.Exit(1)
+	}
+
+	// Scan each file's added lines for sensitive data. A producer goroutine
+	// feeds paths onto filesc, *workers goroutines drain it and push
+	// Findings onto findingsc and errors onto errc, and the reporter below
+	// owns all stdout writes so concurrent workers never interleave output.
+	filesc := make(chan string)
+	findingsc := make(chan gocheck.Finding)
+	errc := make(chan error)
+	donec := make(chan []gocheck.Finding)
+
+	go func() {
+		defer close(filesc)
+		for file := range hunks {
+			if cfg.Skipped(file) {
+				continue
+			}
+			filesc <- file
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range filesc {
+				findings, err := scanPath(file, hunks[file], rules, *checkCID, *checkEntropy, cfg.EntropyBase64Threshold, cfg.EntropyHexThreshold)
+				if err != nil {
+					errc <- err
+					continue
+				}
+				for _, finding := range findings {
+					findingsc <- finding
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(findingsc)
+		close(errc)
+	}()
+
+	go func() {
+		var all []gocheck.Finding
+		for findingsc != nil || errc != nil {
+			select {
+			case finding, ok := <-findingsc:
+				if !ok {
+					findingsc = nil
+					continue
+				}
+				if baseline.Contains(finding) {
+					finding.Severity = "info"
+				}
+				if *format == "text" && *outPath == "" {
+					fmt.Println(finding)
+				}
+				all = append(all, finding)
+			case err, ok := <-errc:
+				if !ok {
+					errc = nil
+					continue
+				}
+				fmt.Fprintln(bannerOut, "\033[1;31mError scanning: \033[0m", err)
+			}
+		}
+		donec <- all
+	}()
+
+	allFindings := <-donec
+
+	if *updateBaseline {
+		if err := gocheck.SaveBaseline(allFindings); err != nil {
+			fmt.Println("\033[1;31mError writing gocheck baseline: \033[0m", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\033[1;32mGOCHECK: Baseline updated with %d finding(s).\033[0m\n", len(allFindings))
+		return
+	}
+
+	if *format != "text" || *outPath != "" {
+		w := os.Stdout
+		if *outPath != "" {
+			f, err := os.Create(*outPath)
+			if err != nil {
+				fmt.Println("\033[1;31mError opening output file: \033[0m", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := gocheck.WriteReport(*format, w, rules, allFindings); err != nil {
+			fmt.Println("\033[1;31mError writing report: \033[0m", err)
+			os.Exit(1)
+		}
+	}
+
+	found := false
+	for _, finding := range allFindings {
+		if finding.Severity != "info" {
+			found = true
+			break
+		}
+	}
+
+	// A CI-oriented format reports via its own output and exit code rather
+	// than the interactive abort message below.
+	if *format != "text" {
+		if found {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// If sensitive information is found, abort the commit
+	if found {
+		fmt.Println("\033[1;31mCommit aborted due to sensitive information found.\033[0m")
+		os.Exit(1)
+	}
+
+	// Print the time it took to run the check
+	duration := time.Since(start)
+	fmt.Printf("\033[1;32mGOCHECK: All files scanned successfully in %s.\033[0m\n", duration)
+}
+
+// scanPath scans a file or folder for sensitive information and returns
+// every match as a Finding. lines holds the staged-diff added lines for
+// path when it names a file directly; a directory has no such diff of
+// its own, so its contents fall back to a full-file scan. base64Threshold
+// and hexThreshold are the entropy detector's Shannon entropy bars (see
+// Config.EntropyBase64Threshold/EntropyHexThreshold).
+func scanPath(path string, lines []gocheck.AddedLine, rules []gocheck.CompiledRule, checkCID, checkEntropy bool, base64Threshold, hexThreshold float64) ([]gocheck.Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("accessing %s: %w", path, err)
+	}
+
+	if info.IsDir() {
+		var findings []gocheck.Finding
+		err := filepath.Walk(path, func(filePath string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return fmt.Errorf("walking %s: %w", filePath, err)
+			}
+			if fileInfo.IsDir() {
+				return nil
+			}
+			fileFindings, err := gocheck.ScanFileFull(filePath, rules, checkCID, checkEntropy, base64Threshold, hexThreshold)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, fileFindings...)
+			return nil
+		})
+		return findings, err
+	}
+
+	// If it's a file, scan only its added lines
+	return gocheck.ScanFile(path, lines, rules, checkCID, checkEntropy, base64Threshold, hexThreshold), nil
+}
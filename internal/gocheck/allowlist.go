@@ -0,0 +1,22 @@
+package gocheck
+
+import "regexp"
+
+// allowPragma matches an inline "gocheck:allow" or scoped
+// "gocheck:allow=RuleName" suppression pragma.
+var allowPragma = regexp.MustCompile(`gocheck:allow(?:=(\w+))?`)
+
+// allowed reports whether line or prevLine carries a gocheck:allow pragma
+// that suppresses ruleName. An unscoped pragma ("gocheck:allow") silences
+// every rule on that line; a scoped one ("gocheck:allow=RuleName") only
+// silences the named rule.
+func allowed(ruleName, line, prevLine string) bool {
+	for _, candidate := range []string{line, prevLine} {
+		if m := allowPragma.FindStringSubmatch(candidate); m != nil {
+			if m[1] == "" || m[1] == ruleName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package gocheck
+
+import "testing"
+
+func TestAllowedUnscopedPragma(t *testing.T) {
+	if !allowed("Token", `apikey: "secret" // gocheck:allow`, "") {
+		t.Error("unscoped gocheck:allow on the line did not suppress the rule")
+	}
+}
+
+func TestAllowedScopedPragma(t *testing.T) {
+	line := `apikey: "secret" // gocheck:allow=Token`
+
+	if !allowed("Token", line, "") {
+		t.Error("gocheck:allow=Token did not suppress Token")
+	}
+	if allowed("Password", line, "") {
+		t.Error("gocheck:allow=Token suppressed an unrelated rule")
+	}
+}
+
+func TestAllowedPragmaOnPrevLine(t *testing.T) {
+	if !allowed("Token", `apikey: "secret"`, "// gocheck:allow") {
+		t.Error("gocheck:allow on the preceding line did not suppress the rule")
+	}
+}
+
+func TestAllowedNoPragma(t *testing.T) {
+	if allowed("Token", `apikey: "secret"`, "") {
+		t.Error("line with no pragma was reported as allowed")
+	}
+}
@@ -0,0 +1,76 @@
+package gocheck
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// baselineFile is the default path gocheck reads and rewrites its
+// baseline from, resolved relative to the working directory.
+const baselineFile = ".gocheck-baseline.json"
+
+// BaselineEntry records a previously-accepted finding so later runs can
+// downgrade it to informational instead of failing the commit again.
+type BaselineEntry struct {
+	Path     string `json:"path"`
+	Rule     string `json:"rule"`
+	LineHash string `json:"lineHash"`
+}
+
+// Baseline is the in-memory form of .gocheck-baseline.json, indexed for
+// fast lookup during a scan.
+type Baseline map[BaselineEntry]bool
+
+// lineHash returns the SHA-256 hex digest of line's trimmed text, used to
+// identify a finding independent of which line number it currently sits
+// on.
+func lineHash(line string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(line)))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadBaseline reads baselineFile from the working directory. A missing
+// file is not an error; it just means nothing has been baselined yet.
+func LoadBaseline() (Baseline, error) {
+	data, err := os.ReadFile(baselineFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Baseline{}, nil
+		}
+		return nil, err
+	}
+
+	var entries []BaselineEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	baseline := make(Baseline, len(entries))
+	for _, e := range entries {
+		baseline[e] = true
+	}
+	return baseline, nil
+}
+
+// Contains reports whether f was already accepted into the baseline.
+func (b Baseline) Contains(f Finding) bool {
+	return b[BaselineEntry{Path: f.Path, Rule: f.Rule, LineHash: lineHash(f.RawLine)}]
+}
+
+// SaveBaseline rewrites baselineFile from findings, used by
+// -update-baseline to accept the current state of the tree.
+func SaveBaseline(findings []Finding) error {
+	entries := make([]BaselineEntry, 0, len(findings))
+	for _, f := range findings {
+		entries = append(entries, BaselineEntry{Path: f.Path, Rule: f.Rule, LineHash: lineHash(f.RawLine)})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(baselineFile, data, 0o644)
+}
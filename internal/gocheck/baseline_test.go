@@ -0,0 +1,64 @@
+package gocheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBaselineRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	findings := []Finding{
+		{Path: "main.go", Rule: "Token", RawLine: `token: "abc123"`},
+	}
+
+	if err := SaveBaseline(findings); err != nil {
+		t.Fatalf("SaveBaseline: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, baselineFile)); err != nil {
+		t.Fatalf("baseline file not written: %v", err)
+	}
+
+	baseline, err := LoadBaseline()
+	if err != nil {
+		t.Fatalf("LoadBaseline: %v", err)
+	}
+
+	if !baseline.Contains(findings[0]) {
+		t.Error("baselined finding not reported as contained")
+	}
+
+	other := Finding{Path: "main.go", Rule: "Token", RawLine: `token: "different"`}
+	if baseline.Contains(other) {
+		t.Error("unrelated finding reported as contained")
+	}
+}
+
+func TestLoadBaselineMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	baseline, err := LoadBaseline()
+	if err != nil {
+		t.Fatalf("LoadBaseline with no baseline file: %v", err)
+	}
+	if len(baseline) != 0 {
+		t.Errorf("got %d entries, want 0", len(baseline))
+	}
+}
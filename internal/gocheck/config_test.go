@@ -0,0 +1,111 @@
+package gocheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigSkipped(t *testing.T) {
+	cfg := Config{Skips: []string{"vendor/**", "*.min.js", "testdata/**"}}
+
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{"vendor/foo.go", true},
+		{"vendor/pkg/foo.go", true},
+		{"vendor/pkg/sub/foo.go", true},
+		{"testdata/fixture.json", true},
+		{"testdata/nested/fixture.json", true},
+		{"static/app.min.js", true},
+		{"static/vendor/app.min.js", true},
+		{"main.go", false},
+		{"internal/gocheck/config.go", false},
+	}
+
+	for _, c := range cases {
+		if got := cfg.Skipped(c.path); got != c.want {
+			t.Errorf("Skipped(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestConfigCompile(t *testing.T) {
+	cfg := Config{Rules: []Rule{{Name: "Token", Regex: `(?i)token`, Severity: "error"}}}
+
+	rules, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("got %d compiled rules, want 1", len(rules))
+	}
+	if !rules[0].pattern.MatchString("a token here") {
+		t.Error("compiled pattern did not match an obviously matching string")
+	}
+}
+
+func TestConfigCompileBadRegex(t *testing.T) {
+	cfg := Config{Rules: []Rule{{Name: "Bad", Regex: `(`}}}
+
+	if _, err := cfg.Compile(); err == nil {
+		t.Fatal("Compile with an invalid regex: got nil error, want non-nil")
+	}
+}
+
+func TestLoadConfigFillsEntropyThresholdDefaults(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	yaml := "rules:\n  - name: Token\n    regex: '(?i)token'\n    severity: error\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gocheck.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.EntropyBase64Threshold != DefaultEntropyBase64Threshold {
+		t.Errorf("EntropyBase64Threshold = %v, want default %v", cfg.EntropyBase64Threshold, DefaultEntropyBase64Threshold)
+	}
+	if cfg.EntropyHexThreshold != DefaultEntropyHexThreshold {
+		t.Errorf("EntropyHexThreshold = %v, want default %v", cfg.EntropyHexThreshold, DefaultEntropyHexThreshold)
+	}
+}
+
+func TestLoadConfigRespectsCustomEntropyThresholds(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	yaml := "entropy_base64_threshold: 5.5\nentropy_hex_threshold: 2.0\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gocheck.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.EntropyBase64Threshold != 5.5 {
+		t.Errorf("EntropyBase64Threshold = %v, want 5.5", cfg.EntropyBase64Threshold)
+	}
+	if cfg.EntropyHexThreshold != 2.0 {
+		t.Errorf("EntropyHexThreshold = %v, want 2.0", cfg.EntropyHexThreshold)
+	}
+}
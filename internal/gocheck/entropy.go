@@ -0,0 +1,98 @@
+package gocheck
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Default thresholds above which a token's Shannon entropy is considered
+// suspicious, used when a config doesn't set its own. Base64-ish strings
+// need a higher bar than hex because their larger alphabet pushes random
+// text's baseline entropy up too. DefaultEntropyBase64Threshold and
+// DefaultEntropyHexThreshold back the Config fields of the same purpose,
+// which callers may override (e.g. via .gocheck.yaml).
+const (
+	DefaultEntropyBase64Threshold = 4.5
+	DefaultEntropyHexThreshold    = 3.0
+	minEntropyTokenLen            = 20
+)
+
+var (
+	base64Alphabet = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+	hexAlphabet    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+	// tokenSplitter breaks a line into candidate secret tokens on
+	// whitespace and the delimiters that commonly wrap them in source.
+	tokenSplitter = regexp.MustCompile(`[\s"',;]+`)
+)
+
+// shannonEntropy computes H = -Σ p_i·log2(p_i) over token's
+// character-frequency distribution.
+func shannonEntropy(token string) float64 {
+	if token == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int, len(token))
+	for _, r := range token {
+		counts[r]++
+	}
+
+	n := float64(len(token))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// entropyFindings scans line for tokens of length >= minEntropyTokenLen
+// and reports any whose Shannon entropy clears base64Threshold or
+// hexThreshold for its alphabet, catching random secrets (API keys, JWT
+// segments, base64 blobs) that the keyword rules miss. hexAlphabet is
+// tested before base64Alphabet because every hex string also matches the
+// (wider) base64 alphabet; classifying hex first is what lets a hex
+// token be judged against hexThreshold instead of always against
+// base64Threshold. prevLine is consulted for an inline gocheck:allow
+// pragma the same way regex rules are.
+func entropyFindings(file string, lineNum int, line, prevLine string, base64Threshold, hexThreshold float64) []Finding {
+	if allowed("HighEntropy", line, prevLine) {
+		return nil
+	}
+
+	var findings []Finding
+
+	for _, token := range tokenSplitter.Split(line, -1) {
+		if len(token) < minEntropyTokenLen {
+			continue
+		}
+
+		switch {
+		case hexAlphabet.MatchString(token):
+			if h := shannonEntropy(token); h >= hexThreshold {
+				findings = append(findings, entropyFinding(file, lineNum, line, token, h))
+			}
+		case base64Alphabet.MatchString(token):
+			if h := shannonEntropy(token); h >= base64Threshold {
+				findings = append(findings, entropyFinding(file, lineNum, line, token, h))
+			}
+		}
+	}
+
+	return findings
+}
+
+func entropyFinding(file string, lineNum int, line, token string, entropy float64) Finding {
+	return Finding{
+		Rule:     "HighEntropy",
+		Severity: "warn",
+		Path:     file,
+		Line:     lineNum,
+		Column:   strings.Index(line, token) + 1,
+		Snippet:  fmt.Sprintf("%s (entropy %.2f)", token, entropy),
+		RawLine:  token,
+	}
+}
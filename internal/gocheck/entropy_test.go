@@ -0,0 +1,68 @@
+package gocheck
+
+import "testing"
+
+func TestEntropyFindingsHexThreshold(t *testing.T) {
+	// A 40-char hex string (max entropy ~4.0) clears the 3.0 hex bar but
+	// would never reach the 4.5 base64 bar, so this also guards against
+	// the hex branch being shadowed by the (wider) base64 alphabet check.
+	line := "commit hash: abcdef0123456789abcdef0123456789abcdef01"
+
+	findings := entropyFindings("file.go", 1, line, "", DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	if findings[0].Rule != "HighEntropy" {
+		t.Errorf("Rule = %q, want HighEntropy", findings[0].Rule)
+	}
+}
+
+func TestEntropyFindingsShortTokenIgnored(t *testing.T) {
+	if findings := entropyFindings("file.go", 1, "short=abc123", "", DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold); len(findings) != 0 {
+		t.Errorf("got %d findings for a too-short token, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestEntropyFindingsAllowPragma(t *testing.T) {
+	line := "key=abcdef0123456789abcdef0123456789abcdef01 // gocheck:allow"
+
+	if findings := entropyFindings("file.go", 1, line, "", DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold); len(findings) != 0 {
+		t.Errorf("got %d findings on a gocheck:allow line, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestEntropyFindingColumn(t *testing.T) {
+	token := "abcdef0123456789abcdef0123456789abcdef01"
+	line := "key: " + token
+
+	findings := entropyFindings("file.go", 1, line, "", DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	if want := len("key: ") + 1; findings[0].Column != want {
+		t.Errorf("Column = %d, want %d", findings[0].Column, want)
+	}
+}
+
+func TestEntropyFindingsCustomHexThreshold(t *testing.T) {
+	// Same 40-char hex token as TestEntropyFindingsHexThreshold (entropy
+	// ~4.0): raising hexThreshold above it should suppress the finding
+	// that the default 3.0 threshold reports.
+	line := "commit hash: abcdef0123456789abcdef0123456789abcdef01"
+
+	if findings := entropyFindings("file.go", 1, line, "", DefaultEntropyBase64Threshold, 4.9); len(findings) != 0 {
+		t.Errorf("got %d findings with hexThreshold above the token's entropy, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestShannonEntropy(t *testing.T) {
+	if h := shannonEntropy(""); h != 0 {
+		t.Errorf("shannonEntropy(\"\") = %v, want 0", h)
+	}
+	if h := shannonEntropy("aaaaaaaaaa"); h != 0 {
+		t.Errorf("shannonEntropy of a single repeated char = %v, want 0", h)
+	}
+	if h := shannonEntropy("ab"); h <= 0 {
+		t.Errorf("shannonEntropy(\"ab\") = %v, want > 0", h)
+	}
+}
@@ -0,0 +1,27 @@
+package gocheck
+
+import "fmt"
+
+// Finding is a single rule match surfaced by a scan. Workers produce
+// Findings; only the reporter goroutine turns them into output.
+type Finding struct {
+	Rule     string
+	Severity string // "error", "warn", or "info" once downgraded by a baseline
+	Path     string
+	Line     int
+	Column   int
+	Snippet  string // text shown to the user, may carry extra detail (e.g. entropy score)
+	RawLine  string // trimmed source line (the flagged token for HighEntropy), used for baseline identity
+}
+
+// String renders a Finding in the "<rule> found in <path> at line N" form
+// gocheck has always printed, prefixed with the severity in brackets.
+// Informational findings (downgraded by a baseline) print in a dimmer
+// color so they don't read as a fresh hit.
+func (f Finding) String() string {
+	color := "\033[1;31m"
+	if f.Severity == "info" {
+		color = "\033[1;90m"
+	}
+	return fmt.Sprintf("%s[%s] %s found in %s at line %d: %s\033[0m", color, f.Severity, f.Rule, f.Path, f.Line, f.Snippet)
+}
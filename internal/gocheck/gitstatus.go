@@ -0,0 +1,205 @@
+package gocheck
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// AddedLine is a single line added by the staged change (or, for a new
+// file, simply one of its lines), tagged with the line number it occupies
+// in the staged (indexed) content.
+type AddedLine struct {
+	LineNum int
+	Text    string
+}
+
+// GetAddedHunks opens the repository rooted at the current directory
+// with go-git and returns, per staged file, only the lines the staged
+// change adds relative to HEAD — not the whole staged file — so a
+// modified file with a legacy secret on an untouched line doesn't fail
+// the commit again. Reading straight from the index and the HEAD tree,
+// rather than shelling out to `git diff` and reading the working tree,
+// means the scan sees exactly what `git commit` is about to record even
+// when the working tree has since diverged from what was staged, and
+// drops the dependency on a git binary being on PATH.
+func GetAddedHunks() (map[string][]AddedLine, error) {
+	repo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening repository: %w", err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("opening worktree: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return nil, fmt.Errorf("reading status: %w", err)
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("reading index: %w", err)
+	}
+	staged := make(map[string]plumbing.Hash, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		staged[entry.Name] = entry.Hash
+	}
+
+	tree, err := headTree(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	hunks := make(map[string][]AddedLine)
+	for path, fileStatus := range status {
+		switch fileStatus.Staging {
+		case git.Added, git.Modified, git.Copied:
+		default:
+			continue
+		}
+
+		hash, ok := staged[path]
+		if !ok {
+			continue
+		}
+
+		stagedLines, err := readBlobLines(repo, hash)
+		if err != nil {
+			return nil, fmt.Errorf("reading staged blob for %s: %w", path, err)
+		}
+
+		headLines, err := readHeadLines(tree, path)
+		if err != nil {
+			return nil, fmt.Errorf("reading HEAD blob for %s: %w", path, err)
+		}
+
+		hunks[path] = addedLines(headLines, stagedLines)
+	}
+
+	return hunks, nil
+}
+
+// headTree returns the tree of the repository's current HEAD commit, or
+// nil if the repository has no commits yet (every staged file is then
+// treated as newly added).
+func headTree(repo *git.Repository) (*object.Tree, error) {
+	ref, err := repo.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolving HEAD: %w", err)
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("reading HEAD commit: %w", err)
+	}
+
+	return commit.Tree()
+}
+
+// readHeadLines returns path's lines as they existed at HEAD, or nil if
+// the repository has no commits yet or path didn't exist there (a new
+// file).
+func readHeadLines(tree *object.Tree, path string) ([]string, error) {
+	if tree == nil {
+		return nil, nil
+	}
+
+	f, err := tree.File(path)
+	if err != nil {
+		if err == object.ErrFileNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	content, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(content), nil
+}
+
+// readBlobLines reads a blob straight from the object store and splits it
+// into lines the same way readHeadLines does, so line endings compare
+// equal when the two are diffed.
+func readBlobLines(repo *git.Repository, hash plumbing.Hash) ([]string, error) {
+	blob, err := repo.BlobObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(string(content)), nil
+}
+
+func splitLines(content string) []string {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// addedLines line-diffs headLines against stagedLines and returns only
+// the lines the staged content adds, each tagged with its line number in
+// the staged (new-file) content. Lines staged carries over unchanged
+// from head are dropped, so a modified file with a legacy secret on an
+// untouched line doesn't trip the scan. headLines is nil for a brand-new
+// file, in which case every staged line counts as added.
+func addedLines(headLines, stagedLines []string) []AddedLine {
+	dmp := diffmatchpatch.New()
+	chars1, chars2, lineArray := dmp.DiffLinesToChars(joinLines(headLines), joinLines(stagedLines))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(chars1, chars2, false), lineArray)
+
+	var added []AddedLine
+	lineNum := 0
+	for _, d := range diffs {
+		lines := splitLines(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			lineNum += len(lines)
+		case diffmatchpatch.DiffInsert:
+			for _, line := range lines {
+				lineNum++
+				added = append(added, AddedLine{LineNum: lineNum, Text: line})
+			}
+		case diffmatchpatch.DiffDelete:
+			// Lines only HEAD had don't occupy a line number in the staged
+			// content.
+		}
+	}
+
+	return added
+}
+
+// joinLines rejoins lines with trailing newlines so diffmatchpatch's
+// line-mode diff can split diff segments back into whole lines.
+func joinLines(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package gocheck
+
+import "testing"
+
+func TestAddedLinesNewFile(t *testing.T) {
+	got := addedLines(nil, []string{"one", "two", "three"})
+
+	want := []AddedLine{{LineNum: 1, Text: "one"}, {LineNum: 2, Text: "two"}, {LineNum: 3, Text: "three"}}
+	if !equalAddedLines(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAddedLinesSkipsUnchangedLines(t *testing.T) {
+	// A legacy secret on an untouched line (head[0]) must not come back as
+	// "added" just because the file around it changed.
+	head := []string{"secret=abc123", "old line"}
+	staged := []string{"secret=abc123", "new line", "another new line"}
+
+	got := addedLines(head, staged)
+
+	want := []AddedLine{{LineNum: 2, Text: "new line"}, {LineNum: 3, Text: "another new line"}}
+	if !equalAddedLines(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAddedLinesNoChange(t *testing.T) {
+	lines := []string{"one", "two"}
+
+	if got := addedLines(lines, lines); len(got) != 0 {
+		t.Errorf("got %+v for an unmodified file, want none", got)
+	}
+}
+
+func equalAddedLines(got, want []AddedLine) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
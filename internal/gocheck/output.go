@@ -0,0 +1,164 @@
+package gocheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonFinding is the JSON-mode wire shape of a single Finding.
+type jsonFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Snippet  string `json:"snippet"`
+}
+
+// sarifLog is a minimal SARIF 2.1.0 log: one tool, one run.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// WriteReport renders findings in the requested format ("text", "json",
+// or "sarif") to w. Unrecognized formats are an error so a typo in
+// -format fails loudly instead of silently falling back to text.
+func WriteReport(format string, w io.Writer, rules []CompiledRule, findings []Finding) error {
+	switch format {
+	case "text":
+		for _, f := range findings {
+			if _, err := fmt.Fprintln(w, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		for _, f := range findings {
+			jf := jsonFinding{Rule: f.Rule, Severity: f.Severity, Path: f.Path, Line: f.Line, Column: f.Column, Snippet: f.Snippet}
+			if err := enc.Encode(jf); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "sarif":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(buildSARIF(rules, findings))
+	default:
+		return fmt.Errorf("unknown output format %q (want text, json, or sarif)", format)
+	}
+}
+
+// sarifRuleDescriptors builds the tool.driver.rules entries SARIF
+// consumers (e.g. GitHub code scanning) use to show a rule's name and
+// description alongside each result.
+func sarifRuleDescriptors(rules []CompiledRule) []sarifRule {
+	descriptors := make([]sarifRule, 0, len(rules)+1)
+	for _, r := range rules {
+		descriptors = append(descriptors, sarifRule{
+			ID:               r.Name,
+			Name:             r.Name,
+			ShortDescription: sarifMessage{Text: fmt.Sprintf("%s pattern match (%s severity)", r.Name, r.Severity)},
+		})
+	}
+	// HighEntropy is a built-in detector, not a configured rule, so it
+	// needs its own static descriptor.
+	descriptors = append(descriptors, sarifRule{
+		ID:               "HighEntropy",
+		Name:             "HighEntropy",
+		ShortDescription: sarifMessage{Text: "High-entropy token that may be an unkeyworded secret"},
+	})
+	return descriptors
+}
+
+// sarifLevel maps a Finding's severity to the SARIF result levels code
+// scanning understands.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+func buildSARIF(rules []CompiledRule, findings []Finding) sarifLog {
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, sarifResult{
+			RuleID:  f.Rule,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Snippet},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.Path},
+					Region:           sarifRegion{StartLine: f.Line, StartColumn: f.Column},
+				},
+			}},
+		})
+	}
+
+	return sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "gocheck", Rules: sarifRuleDescriptors(rules)}},
+			Results: results,
+		}},
+	}
+}
@@ -0,0 +1,66 @@
+package gocheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteReportJSONCarriesColumn(t *testing.T) {
+	findings := []Finding{{Rule: "Token", Severity: "error", Path: "main.go", Line: 3, Column: 7, Snippet: "token: ..."}}
+
+	var buf bytes.Buffer
+	if err := WriteReport("json", &buf, nil, findings); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	var jf jsonFinding
+	if err := json.Unmarshal(buf.Bytes(), &jf); err != nil {
+		t.Fatalf("unmarshaling report: %v", err)
+	}
+	if jf.Column != 7 {
+		t.Errorf("Column = %d, want 7", jf.Column)
+	}
+}
+
+func TestWriteReportSARIFCarriesColumn(t *testing.T) {
+	findings := []Finding{{Rule: "Token", Severity: "error", Path: "main.go", Line: 3, Column: 7, Snippet: "token: ..."}}
+
+	var buf bytes.Buffer
+	if err := WriteReport("sarif", &buf, nil, findings); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshaling report: %v", err)
+	}
+	region := log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region
+	if region.StartColumn != 7 {
+		t.Errorf("StartColumn = %d, want 7", region.StartColumn)
+	}
+}
+
+func TestWriteReportUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteReport("xml", &buf, nil, nil)
+	if err == nil {
+		t.Fatal("WriteReport with an unknown format: got nil error, want non-nil")
+	}
+	if !strings.Contains(err.Error(), "xml") {
+		t.Errorf("error %q does not mention the offending format", err)
+	}
+}
+
+func TestWriteReportText(t *testing.T) {
+	findings := []Finding{{Rule: "Token", Severity: "error", Path: "main.go", Line: 3, Snippet: "token: ..."}}
+
+	var buf bytes.Buffer
+	if err := WriteReport("text", &buf, nil, findings); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Token") {
+		t.Errorf("text report %q does not mention the rule name", buf.String())
+	}
+}
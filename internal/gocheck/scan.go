@@ -0,0 +1,91 @@
+package gocheck
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ScanFile scans a file's added lines against rules and returns every
+// match as a Finding (a rule tagged "cid" only runs when checkCID is
+// set). When checkEntropy is set, lines are also run through the
+// high-entropy token detector using base64Threshold and hexThreshold
+// (see Config.EntropyBase64Threshold/EntropyHexThreshold). It never
+// writes to stdout; callers own that.
+func ScanFile(file string, lines []AddedLine, rules []CompiledRule, checkCID, checkEntropy bool, base64Threshold, hexThreshold float64) []Finding {
+	var findings []Finding
+
+	for i, al := range lines {
+		// Only an added line directly above counts as prevLine for a
+		// gocheck:allow pragma; an unchanged line above is not seen.
+		var prevLine string
+		if i > 0 && lines[i-1].LineNum == al.LineNum-1 {
+			prevLine = lines[i-1].Text
+		}
+		findings = append(findings, matchLine(file, al.LineNum, al.Text, prevLine, rules, checkCID, checkEntropy, base64Threshold, hexThreshold)...)
+	}
+
+	return findings
+}
+
+// ScanFileFull scans every line of a file on disk against rules and
+// returns every match as a Finding. Used when walking a directory, where
+// no staged-diff hunk information is available to narrow the scan.
+func ScanFileFull(file string, rules []CompiledRule, checkCID, checkEntropy bool, base64Threshold, hexThreshold float64) ([]Finding, error) {
+	var findings []Finding
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", file, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	var prevLine string
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		findings = append(findings, matchLine(file, lineNum, line, prevLine, rules, checkCID, checkEntropy, base64Threshold, hexThreshold)...)
+		prevLine = line
+	}
+
+	if err := scanner.Err(); err != nil {
+		return findings, fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	return findings, nil
+}
+
+// matchLine runs rules (and, if enabled, the entropy detector) against a
+// single line and returns every match as a Finding.
+func matchLine(file string, lineNum int, line, prevLine string, rules []CompiledRule, checkCID, checkEntropy bool, base64Threshold, hexThreshold float64) []Finding {
+	var findings []Finding
+
+	for _, rule := range rules {
+		if rule.hasTag("cid") && !checkCID {
+			continue
+		}
+		if allowed(rule.Name, line, prevLine) {
+			continue
+		}
+		if loc := rule.pattern.FindStringIndex(line); loc != nil {
+			findings = append(findings, Finding{
+				Rule:     rule.Name,
+				Severity: rule.Severity,
+				Path:     file,
+				Line:     lineNum,
+				Column:   loc[0] + 1,
+				Snippet:  strings.TrimSpace(line),
+				RawLine:  strings.TrimSpace(line),
+			})
+		}
+	}
+
+	if checkEntropy {
+		findings = append(findings, entropyFindings(file, lineNum, line, prevLine, base64Threshold, hexThreshold)...)
+	}
+
+	return findings
+}
@@ -0,0 +1,56 @@
+package gocheck
+
+import "testing"
+
+func TestScanFileFindsRuleMatch(t *testing.T) {
+	cfg := Config{Rules: []Rule{{Name: "Token", Regex: `(?i)token[=:]\s*\S+`, Severity: "error", Tags: []string{"token"}}}}
+	rules, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	lines := []AddedLine{{LineNum: 5, Text: `token=abc123`}}
+	findings := ScanFile("main.go", lines, rules, true, false, DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold)
+
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	if findings[0].Line != 5 {
+		t.Errorf("Line = %d, want 5", findings[0].Line)
+	}
+}
+
+func TestScanFileCIDRuleRespectsFlag(t *testing.T) {
+	cfg := Config{Rules: []Rule{{Name: "Email", Regex: `[\w.]+@[\w.]+`, Severity: "warn", Tags: []string{"cid"}}}}
+	rules, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	lines := []AddedLine{{LineNum: 1, Text: "contact: jane@example.com"}}
+
+	if findings := ScanFile("main.go", lines, rules, false, false, DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold); len(findings) != 0 {
+		t.Errorf("got %d findings with -cid=false, want 0: %+v", len(findings), findings)
+	}
+	if findings := ScanFile("main.go", lines, rules, true, false, DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold); len(findings) != 1 {
+		t.Errorf("got %d findings with -cid=true, want 1: %+v", len(findings), findings)
+	}
+}
+
+func TestScanFileColumnMatchesOffset(t *testing.T) {
+	cfg := Config{Rules: []Rule{{Name: "Token", Regex: `token=\S+`, Severity: "error"}}}
+	rules, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	lines := []AddedLine{{LineNum: 1, Text: "prefix token=abc123"}}
+	findings := ScanFile("main.go", lines, rules, true, false, DefaultEntropyBase64Threshold, DefaultEntropyHexThreshold)
+
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	if want := len("prefix ") + 1; findings[0].Column != want {
+		t.Errorf("Column = %d, want %d", findings[0].Column, want)
+	}
+}